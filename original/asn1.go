@@ -0,0 +1,146 @@
+package paillier
+
+import (
+	"crypto"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"math/big"
+)
+
+// ErrInvalidPEMBlock is returned when a PEM-encoded Paillier key is missing,
+// malformed, or carries an unexpected block type.
+var ErrInvalidPEMBlock = errors.New("paillier: invalid PEM block")
+
+const (
+	pemPrivateKeyType = "PAILLIER PRIVATE KEY"
+	pemPublicKeyType  = "PAILLIER PUBLIC KEY"
+)
+
+var (
+	_ crypto.PublicKey  = (*PublicKey)(nil)
+	_ crypto.PrivateKey = (*PrivateKey)(nil)
+	_ crypto.Decrypter  = (*PrivateKey)(nil)
+)
+
+// pkcs1PrivateKey mirrors the layout of PKCS#1's RSAPrivateKey: a versioned
+// ASN.1 SEQUENCE holding every field needed to reconstruct a PrivateKey.
+type pkcs1PrivateKey struct {
+	Version int
+	N       *big.Int
+	G       *big.Int
+	Lambda  *big.Int
+	Mu      *big.Int
+	P       *big.Int
+	Q       *big.Int
+}
+
+type pkcs1PublicKey struct {
+	N *big.Int
+	G *big.Int
+}
+
+// Size returns the byte length of the N^2 modulus, mirroring
+// rsa.PublicKey.Size.
+func (pub *PublicKey) Size() int {
+	return (pub.NSquared.BitLen() + 7) / 8
+}
+
+// Public returns the public half of priv, implementing crypto.Decrypter.
+func (priv *PrivateKey) Public() crypto.PublicKey {
+	return &priv.PublicKey
+}
+
+// MarshalPKCS1PaillierPrivateKey serializes a Paillier private key to ASN.1
+// DER, analogous to x509.MarshalPKCS1PrivateKey for RSA.
+func MarshalPKCS1PaillierPrivateKey(priv *PrivateKey) ([]byte, error) {
+	return asn1.Marshal(pkcs1PrivateKey{
+		Version: 0,
+		N:       priv.N,
+		G:       priv.G,
+		Lambda:  priv.L,
+		Mu:      priv.U,
+		P:       priv.P,
+		Q:       priv.Q,
+	})
+}
+
+// ParsePKCS1PaillierPrivateKey parses an ASN.1 DER-encoded Paillier private
+// key produced by MarshalPKCS1PaillierPrivateKey and recomputes its CRT
+// acceleration values.
+func ParsePKCS1PaillierPrivateKey(der []byte) (*PrivateKey, error) {
+	var k pkcs1PrivateKey
+	if _, err := asn1.Unmarshal(der, &k); err != nil {
+		return nil, err
+	}
+
+	priv := &PrivateKey{
+		PublicKey: PublicKey{
+			N:        k.N,
+			G:        k.G,
+			NSquared: new(big.Int).Mul(k.N, k.N),
+		},
+		L: k.Lambda,
+		U: k.Mu,
+		P: k.P,
+		Q: k.Q,
+	}
+	priv.Precompute()
+	return priv, nil
+}
+
+// MarshalPKCS1PaillierPublicKey serializes a Paillier public key to ASN.1
+// DER.
+func MarshalPKCS1PaillierPublicKey(pub *PublicKey) ([]byte, error) {
+	return asn1.Marshal(pkcs1PublicKey{N: pub.N, G: pub.G})
+}
+
+// ParsePKCS1PaillierPublicKey parses an ASN.1 DER-encoded Paillier public
+// key produced by MarshalPKCS1PaillierPublicKey.
+func ParsePKCS1PaillierPublicKey(der []byte) (*PublicKey, error) {
+	var k pkcs1PublicKey
+	if _, err := asn1.Unmarshal(der, &k); err != nil {
+		return nil, err
+	}
+	return &PublicKey{N: k.N, G: k.G, NSquared: new(big.Int).Mul(k.N, k.N)}, nil
+}
+
+// MarshalPaillierPrivateKeyPEM wraps MarshalPKCS1PaillierPrivateKey in a PEM
+// block of type "PAILLIER PRIVATE KEY".
+func MarshalPaillierPrivateKeyPEM(priv *PrivateKey) ([]byte, error) {
+	der, err := MarshalPKCS1PaillierPrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPrivateKeyType, Bytes: der}), nil
+}
+
+// ParsePaillierPrivateKeyPEM decodes a PEM-encoded Paillier private key
+// produced by MarshalPaillierPrivateKeyPEM.
+func ParsePaillierPrivateKeyPEM(data []byte) (*PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemPrivateKeyType {
+		return nil, ErrInvalidPEMBlock
+	}
+	return ParsePKCS1PaillierPrivateKey(block.Bytes)
+}
+
+// MarshalPaillierPublicKeyPEM wraps MarshalPKCS1PaillierPublicKey in a PEM
+// block of type "PAILLIER PUBLIC KEY".
+func MarshalPaillierPublicKeyPEM(pub *PublicKey) ([]byte, error) {
+	der, err := MarshalPKCS1PaillierPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: pemPublicKeyType, Bytes: der}), nil
+}
+
+// ParsePaillierPublicKeyPEM decodes a PEM-encoded Paillier public key
+// produced by MarshalPaillierPublicKeyPEM.
+func ParsePaillierPublicKeyPEM(data []byte) (*PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil || block.Type != pemPublicKeyType {
+		return nil, ErrInvalidPEMBlock
+	}
+	return ParsePKCS1PaillierPublicKey(block.Bytes)
+}
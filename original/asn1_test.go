@@ -0,0 +1,116 @@
+package paillier
+
+import (
+	"crypto"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestPublicKeySize(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	if got, want := priv.Size(), (priv.NSquared.BitLen()+7)/8; got != want {
+		t.Fatalf("Size() = %d, want %d", got, want)
+	}
+}
+
+func TestPrivateKeyIsCryptoDecrypter(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	var d crypto.Decrypter = priv
+	if pub, ok := d.Public().(*PublicKey); !ok || pub.N.Cmp(priv.N) != 0 {
+		t.Fatalf("Public() did not return the matching *PublicKey")
+	}
+
+	c, err := priv.Encrypt(big.NewInt(13).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	m, err := d.Decrypt(rand.Reader, c, nil)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if new(big.Int).SetBytes(m).Cmp(big.NewInt(13)) != 0 {
+		t.Fatalf("got %s, want 13", new(big.Int).SetBytes(m))
+	}
+}
+
+func TestPKCS1PrivateKeyRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	der, err := MarshalPKCS1PaillierPrivateKey(priv)
+	if err != nil {
+		t.Fatalf("MarshalPKCS1PaillierPrivateKey: %v", err)
+	}
+	got, err := ParsePKCS1PaillierPrivateKey(der)
+	if err != nil {
+		t.Fatalf("ParsePKCS1PaillierPrivateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(7).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	m, err := got.Decrypt(nil, c, nil)
+	if err != nil {
+		t.Fatalf("Decrypt with parsed key: %v", err)
+	}
+	if new(big.Int).SetBytes(m).Cmp(big.NewInt(7)) != 0 {
+		t.Fatalf("got %s, want 7", new(big.Int).SetBytes(m))
+	}
+}
+
+func TestPEMPrivateKeyRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	pemBytes, err := MarshalPaillierPrivateKeyPEM(priv)
+	if err != nil {
+		t.Fatalf("MarshalPaillierPrivateKeyPEM: %v", err)
+	}
+	got, err := ParsePaillierPrivateKeyPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("ParsePaillierPrivateKeyPEM: %v", err)
+	}
+	if got.N.Cmp(priv.N) != 0 {
+		t.Fatalf("parsed key N mismatch")
+	}
+
+	if _, err := ParsePaillierPrivateKeyPEM([]byte("not pem")); err != ErrInvalidPEMBlock {
+		t.Fatalf("got err %v, want ErrInvalidPEMBlock", err)
+	}
+}
+
+func TestPEMPublicKeyRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	pemBytes, err := MarshalPaillierPublicKeyPEM(&priv.PublicKey)
+	if err != nil {
+		t.Fatalf("MarshalPaillierPublicKeyPEM: %v", err)
+	}
+	got, err := ParsePaillierPublicKeyPEM(pemBytes)
+	if err != nil {
+		t.Fatalf("ParsePaillierPublicKeyPEM: %v", err)
+	}
+	if got.N.Cmp(priv.N) != 0 || got.G.Cmp(priv.G) != 0 {
+		t.Fatalf("parsed public key mismatch")
+	}
+
+	if _, err := ParsePaillierPublicKeyPEM([]byte("not pem")); err != ErrInvalidPEMBlock {
+		t.Fatalf("got err %v, want ErrInvalidPEMBlock", err)
+	}
+}
@@ -0,0 +1,65 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestDJEncryptDecryptRoundTrip(t *testing.T) {
+	for s := 1; s <= 4; s++ {
+		s := s
+		t.Run(string(rune('0'+s)), func(t *testing.T) {
+			priv, err := GenerateKeyS(rand.Reader, 256, s)
+			if err != nil {
+				t.Fatalf("GenerateKeyS: %v", err)
+			}
+
+			want := big.NewInt(123456789)
+			c, err := priv.EncryptS(want.Bytes())
+			if err != nil {
+				t.Fatalf("EncryptS: %v", err)
+			}
+
+			got, err := priv.DecryptS(c)
+			if err != nil {
+				t.Fatalf("DecryptS: %v", err)
+			}
+
+			if new(big.Int).SetBytes(got).Cmp(want) != 0 {
+				t.Fatalf("s=%d: got %s, want %s", s, new(big.Int).SetBytes(got), want)
+			}
+		})
+	}
+}
+
+func TestDJHomomorphicAddS(t *testing.T) {
+	priv, err := GenerateKeyS(rand.Reader, 256, 2)
+	if err != nil {
+		t.Fatalf("GenerateKeyS: %v", err)
+	}
+
+	a, b := big.NewInt(40), big.NewInt(2)
+	ca, err := priv.EncryptS(a.Bytes())
+	if err != nil {
+		t.Fatalf("EncryptS: %v", err)
+	}
+	cb, err := priv.EncryptS(b.Bytes())
+	if err != nil {
+		t.Fatalf("EncryptS: %v", err)
+	}
+
+	sum, err := priv.HomomorphicAddS(ca, cb)
+	if err != nil {
+		t.Fatalf("HomomorphicAddS: %v", err)
+	}
+
+	got, err := priv.DecryptS(sum)
+	if err != nil {
+		t.Fatalf("DecryptS: %v", err)
+	}
+
+	if new(big.Int).SetBytes(got).Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("got %s, want 42", new(big.Int).SetBytes(got))
+	}
+}
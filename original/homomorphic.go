@@ -0,0 +1,82 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"math/big"
+)
+
+// HomomorphicScalarMul returns an encryption of k*m given an encryption of
+// m, exploiting that c^k = (g^m r^n)^k = g^(km) (r^k)^n mod n^2.
+func (pub *PublicKey) HomomorphicScalarMul(c, k []byte) ([]byte, error) {
+	cipher := new(big.Int).SetBytes(c)
+	if cipher.Cmp(pub.NSquared) >= 0 {
+		return nil, ErrLargeCipher
+	}
+	scalar := new(big.Int).SetBytes(k)
+
+	C := new(big.Int).Exp(cipher, scalar, pub.NSquared)
+	return C.Bytes(), nil
+}
+
+// HomomorphicNeg returns an encryption of -m given an encryption of m.
+func (pub *PublicKey) HomomorphicNeg(c []byte) ([]byte, error) {
+	cipher := new(big.Int).SetBytes(c)
+	if cipher.Cmp(pub.NSquared) >= 0 {
+		return nil, ErrLargeCipher
+	}
+
+	C := new(big.Int).ModInverse(cipher, pub.NSquared)
+	if C == nil {
+		return nil, ErrLargeCipher
+	}
+	return C.Bytes(), nil
+}
+
+// HomomorphicSub returns an encryption of m1-m2 given encryptions of m1 and
+// m2.
+func (pub *PublicKey) HomomorphicSub(c1, c2 []byte) ([]byte, error) {
+	negC2, err := pub.HomomorphicNeg(c2)
+	if err != nil {
+		return nil, err
+	}
+	return pub.HomomorphicEncTwo(c1, negC2)
+}
+
+// HomomorphicAddConst returns an encryption of m+k given an encryption of m
+// and a plaintext constant k.
+func (pub *PublicKey) HomomorphicAddConst(c, k []byte) ([]byte, error) {
+	cipher := new(big.Int).SetBytes(c)
+	if cipher.Cmp(pub.NSquared) >= 0 {
+		return nil, ErrLargeCipher
+	}
+	m := new(big.Int).SetBytes(k)
+
+	C := new(big.Int).Mod(
+		new(big.Int).Mul(cipher, new(big.Int).Exp(pub.G, m, pub.NSquared)),
+		pub.NSquared,
+	)
+	return C.Bytes(), nil
+}
+
+// Rerandomize multiplies c by a fresh encryption of zero, producing a new
+// ciphertext for the same plaintext that is indistinguishable from a fresh
+// encryption. This matters because the ciphertexts produced by the
+// homomorphic operators above otherwise carry a trace of the operations
+// that produced them.
+func (pub *PublicKey) Rerandomize(c []byte) ([]byte, error) {
+	cipher := new(big.Int).SetBytes(c)
+	if cipher.Cmp(pub.NSquared) >= 0 {
+		return nil, ErrLargeCipher
+	}
+
+	r, err := randomInZNStar(rand.Reader, pub.N)
+	if err != nil {
+		return nil, err
+	}
+
+	C := new(big.Int).Mod(
+		new(big.Int).Mul(cipher, new(big.Int).Exp(r, pub.N, pub.NSquared)),
+		pub.NSquared,
+	)
+	return C.Bytes(), nil
+}
@@ -0,0 +1,189 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestHomomorphicScalarMul(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(6).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	c2, err := priv.HomomorphicScalarMul(c, big.NewInt(7).Bytes())
+	if err != nil {
+		t.Fatalf("HomomorphicScalarMul: %v", err)
+	}
+
+	got, _, err := decrypt(priv, c2)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("got %s, want 42", got)
+	}
+}
+
+func TestHomomorphicNeg(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(5).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	negC, err := priv.HomomorphicNeg(c)
+	if err != nil {
+		t.Fatalf("HomomorphicNeg: %v", err)
+	}
+
+	sum, err := priv.HomomorphicEncTwo(c, negC)
+	if err != nil {
+		t.Fatalf("HomomorphicEncTwo: %v", err)
+	}
+
+	got, _, err := decrypt(priv, sum)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got.Sign() != 0 {
+		t.Fatalf("got %s, want 0", got)
+	}
+}
+
+func TestHomomorphicSub(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c1, err := priv.Encrypt(big.NewInt(10).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	c2, err := priv.Encrypt(big.NewInt(4).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	diff, err := priv.HomomorphicSub(c1, c2)
+	if err != nil {
+		t.Fatalf("HomomorphicSub: %v", err)
+	}
+
+	got, _, err := decrypt(priv, diff)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got.Cmp(big.NewInt(6)) != 0 {
+		t.Fatalf("got %s, want 6", got)
+	}
+}
+
+func TestHomomorphicAddConst(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(3).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	c2, err := priv.HomomorphicAddConst(c, big.NewInt(39).Bytes())
+	if err != nil {
+		t.Fatalf("HomomorphicAddConst: %v", err)
+	}
+
+	got, _, err := decrypt(priv, c2)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got.Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("got %s, want 42", got)
+	}
+}
+
+func TestRerandomizePreservesPlaintext(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(17).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	c2, err := priv.Rerandomize(c)
+	if err != nil {
+		t.Fatalf("Rerandomize: %v", err)
+	}
+	if new(big.Int).SetBytes(c2).Cmp(new(big.Int).SetBytes(c)) == 0 {
+		t.Fatalf("Rerandomize returned the same ciphertext bytes")
+	}
+
+	got, _, err := decrypt(priv, c2)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got.Cmp(big.NewInt(17)) != 0 {
+		t.Fatalf("got %s, want 17", got)
+	}
+}
+
+func TestHomomorphicOperatorsRejectOversizedCiphertext(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	oversized := new(big.Int).Add(priv.NSquared, one).Bytes()
+	valid, err := priv.Encrypt(big.NewInt(1).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	if _, err := priv.HomomorphicEncTwo(valid, oversized); err != ErrLargeCipher {
+		t.Errorf("HomomorphicEncTwo(valid, oversized): got err %v, want ErrLargeCipher", err)
+	}
+	if _, err := priv.HomomorphicEncTwo(oversized, valid); err != ErrLargeCipher {
+		t.Errorf("HomomorphicEncTwo(oversized, valid): got err %v, want ErrLargeCipher", err)
+	}
+	if _, err := priv.HomomorphicScalarMul(oversized, big.NewInt(2).Bytes()); err != ErrLargeCipher {
+		t.Errorf("HomomorphicScalarMul: got err %v, want ErrLargeCipher", err)
+	}
+	if _, err := priv.HomomorphicNeg(oversized); err != ErrLargeCipher {
+		t.Errorf("HomomorphicNeg: got err %v, want ErrLargeCipher", err)
+	}
+	if _, err := priv.HomomorphicSub(valid, oversized); err != ErrLargeCipher {
+		t.Errorf("HomomorphicSub: got err %v, want ErrLargeCipher", err)
+	}
+	if _, err := priv.HomomorphicAddConst(oversized, big.NewInt(1).Bytes()); err != ErrLargeCipher {
+		t.Errorf("HomomorphicAddConst: got err %v, want ErrLargeCipher", err)
+	}
+	if _, err := priv.Rerandomize(oversized); err != ErrLargeCipher {
+		t.Errorf("Rerandomize: got err %v, want ErrLargeCipher", err)
+	}
+}
+
+// decrypt is a small helper wrapping the crypto.Decrypter-shaped Decrypt
+// method for tests that only care about the plaintext.
+func decrypt(priv *PrivateKey, cipherText []byte) (*big.Int, []byte, error) {
+	m, err := priv.Decrypt(nil, cipherText, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	return new(big.Int).SetBytes(m), m, nil
+}
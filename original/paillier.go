@@ -1,6 +1,7 @@
 package paillier
 
 import (
+	"crypto"
 	"crypto/rand"
 	"errors"
 	"io"
@@ -16,6 +17,17 @@ type PrivateKey struct {
 	PublicKey
 	L *big.Int // phi(n), (p-1)*(q-1)
 	U *big.Int // l^-1 mod n
+
+	// P, Q and the following CRT values accelerate Decrypt, following the
+	// pattern of crypto/rsa.PrecomputedValues. GenerateKey retains P and Q;
+	// PP, QQ, Hp and Hq are derived from them by Precompute, which
+	// GenerateKey calls automatically.
+	P  *big.Int // prime factor of N
+	Q  *big.Int // prime factor of N
+	PP *big.Int // p^-1 mod q
+	QQ *big.Int // q^-1 mod p
+	Hp *big.Int // L(g^(p-1) mod p^2)^-1 mod p
+	Hq *big.Int // L(g^(q-1) mod q^2)^-1 mod q
 }
 
 // PublicKey represents Paillier public key.
@@ -54,7 +66,7 @@ func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
 	// l^(-1) mod n
 	u := new(big.Int).ModInverse(l, n)
 
-	return &PrivateKey{
+	priv := &PrivateKey{
 		PublicKey: PublicKey{
 			N:        n,
 			NSquared: nsquare,
@@ -62,22 +74,49 @@ func GenerateKey(random io.Reader, bits int) (*PrivateKey, error) {
 		},
 		L: l,
 		U: u,
-	}, nil
+		P: p,
+		Q: q,
+	}
+	priv.Precompute()
+	return priv, nil
+}
+
+// Precompute fills in the CRT acceleration values (PP, QQ, Hp, Hq) from the
+// primes P and Q. GenerateKey calls this automatically; a PrivateKey built
+// by hand (e.g. after unmarshaling) must call it before Decrypt.
+func (priv *PrivateKey) Precompute() {
+	p, q := priv.P, priv.Q
+	pSquared := new(big.Int).Mul(p, p)
+	qSquared := new(big.Int).Mul(q, q)
+
+	priv.PP = new(big.Int).ModInverse(p, q)
+	priv.QQ = new(big.Int).ModInverse(q, p)
+
+	gp := new(big.Int).Exp(priv.G, new(big.Int).Sub(p, one), pSquared)
+	priv.Hp = new(big.Int).ModInverse(lFunction(gp, p), p)
+
+	gq := new(big.Int).Exp(priv.G, new(big.Int).Sub(q, one), qSquared)
+	priv.Hq = new(big.Int).ModInverse(lFunction(gq, q), q)
+}
+
+// lFunction computes L(x) = (x-1)/n, the standard Paillier L-function.
+func lFunction(x, n *big.Int) *big.Int {
+	return new(big.Int).Div(new(big.Int).Sub(x, one), n)
 }
 
 // Encrypt encrypts a plain text represented as a byte array. It returns
-// an error if the plain text value is larger than the modulus N^2 of the Public key.
+// an error if the plain text value is not smaller than the modulus N of the Public key.
 func (pub *PublicKey) Encrypt(plainText []byte) ([]byte, error) {
-	r, err := rand.Prime(rand.Reader, pub.N.BitLen())
-	if err != nil {
-		return nil, err
-	}
-
 	m := new(big.Int).SetBytes(plainText)
-	if m.Cmp(pub.NSquared) == 1 { //  m < N^2
+	if m.Cmp(pub.N) >= 0 { // m < N
 		return nil, ErrLargeMessage
 	}
 
+	r, err := randomInZNStar(rand.Reader, pub.N)
+	if err != nil {
+		return nil, err
+	}
+
 	// c = g^m * r^n mod n^2
 	n := pub.N
 	c := new(big.Int).Mod(
@@ -90,29 +129,56 @@ func (pub *PublicKey) Encrypt(plainText []byte) ([]byte, error) {
 	return c.Bytes(), nil
 }
 
-// Decrypt decrypts the passed cipher text. It returns
-// an error if the cipher text value is larger than the modulus N^2 of Public key.
-func (priv *PrivateKey) Decrypt(cipherText []byte) ([]byte, error) {
+// randomInZNStar samples r uniformly from Z_N^*, the multiplicative group of
+// integers coprime to N, retrying on the negligibly rare case that a
+// uniform sample in [0, N) shares a factor with N.
+func randomInZNStar(random io.Reader, n *big.Int) (*big.Int, error) {
+	for {
+		r, err := rand.Int(random, n)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() == 0 {
+			continue
+		}
+		if new(big.Int).GCD(nil, nil, r, n).Cmp(one) == 0 {
+			return r, nil
+		}
+	}
+}
+
+// Decrypt decrypts the passed cipher text using the CRT-accelerated
+// algorithm: it recovers m mod p and m mod q independently, each roughly
+// an eighth of the cost of the textbook c^L mod N^2 exponentiation (half the
+// exponent length, half the modulus length), then CRT-combines
+// them, giving roughly a 4x speedup over computing c^L mod N^2 directly. It
+// returns an error if the cipher text value is not smaller than the modulus
+// N^2 of the Public key. rand and opts are accepted but unused, satisfying
+// crypto.Decrypter; Paillier decryption needs no randomness and takes no
+// options.
+func (priv *PrivateKey) Decrypt(rand io.Reader, cipherText []byte, opts crypto.DecrypterOpts) ([]byte, error) {
 	c := new(big.Int).SetBytes(cipherText)
-	if c.Cmp(priv.NSquared) == 1 { // c < n^2
+	if c.Cmp(priv.NSquared) >= 0 { // c < n^2
 		return nil, ErrLargeCipher
 	}
 
-	// c^l mod n^2
-	a := new(big.Int).Exp(c, priv.L, priv.NSquared)
+	pSquared := new(big.Int).Mul(priv.P, priv.P)
+	qSquared := new(big.Int).Mul(priv.Q, priv.Q)
 
-	// let L(a) = l(a) and should not confuse it with 'priv.L'.
-	// So, l(a) = (a - 1) / n
-	l := new(big.Int).Div(
-		new(big.Int).Sub(a, one),
-		priv.N,
-	)
+	// m_p = L(c^(p-1) mod p^2) * Hp mod p
+	cp := new(big.Int).Exp(c, new(big.Int).Sub(priv.P, one), pSquared)
+	mp := new(big.Int).Mod(new(big.Int).Mul(lFunction(cp, priv.P), priv.Hp), priv.P)
+
+	// m_q = L(c^(q-1) mod q^2) * Hq mod q
+	cq := new(big.Int).Exp(c, new(big.Int).Sub(priv.Q, one), qSquared)
+	mq := new(big.Int).Mod(new(big.Int).Mul(lFunction(cq, priv.Q), priv.Hq), priv.Q)
+
+	// CRT-combine m_p and m_q into m mod N, following the same Garner's
+	// formula as crypto/rsa's CRT decryption.
+	h := new(big.Int).Mod(new(big.Int).Mul(new(big.Int).Sub(mp, mq), priv.QQ), priv.P)
+	m := new(big.Int).Add(mq, new(big.Int).Mul(h, priv.Q))
+	m.Mod(m, priv.N)
 
-	// m = L(c^l mod n^2) * u mod n
-	m := new(big.Int).Mod(
-		new(big.Int).Mul(l, priv.U),
-		priv.N,
-	)
 	return m.Bytes(), nil
 }
 
@@ -122,7 +188,7 @@ func (priv *PrivateKey) Decrypt(cipherText []byte) ([]byte, error) {
 func (pub *PublicKey) HomomorphicEncTwo(c1, c2 []byte) ([]byte, error) {
 	cipherA := new(big.Int).SetBytes(c1)
 	cipherB := new(big.Int).SetBytes(c2)
-	if cipherA.Cmp(pub.NSquared) == 1 && cipherB.Cmp(pub.NSquared) == 1 { // (c1 & c2) < N^2
+	if cipherA.Cmp(pub.NSquared) >= 0 || cipherB.Cmp(pub.NSquared) >= 0 { // (c1 & c2) < N^2
 		return nil, ErrLargeCipher
 	}
 
@@ -0,0 +1,123 @@
+package paillier
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+func TestEncryptDecryptRoundTrip(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	for _, want := range []int64{0, 1, 42, 123456789} {
+		c, err := priv.Encrypt(big.NewInt(want).Bytes())
+		if err != nil {
+			t.Fatalf("Encrypt(%d): %v", want, err)
+		}
+		m, err := priv.Decrypt(nil, c, nil)
+		if err != nil {
+			t.Fatalf("Decrypt(%d): %v", want, err)
+		}
+		if got := new(big.Int).SetBytes(m); got.Cmp(big.NewInt(want)) != 0 {
+			t.Errorf("got %s, want %d", got, want)
+		}
+	}
+}
+
+func TestDecryptCRTMatchesPrecomputedValues(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	if priv.P == nil || priv.Q == nil || priv.PP == nil || priv.QQ == nil || priv.Hp == nil || priv.Hq == nil {
+		t.Fatal("GenerateKey did not populate CRT fields")
+	}
+	if new(big.Int).Mul(priv.P, priv.Q).Cmp(priv.N) != 0 {
+		t.Fatal("P*Q != N")
+	}
+
+	c, err := priv.Encrypt(big.NewInt(99).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	m, err := priv.Decrypt(nil, c, nil)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if new(big.Int).SetBytes(m).Cmp(big.NewInt(99)) != 0 {
+		t.Fatalf("got %s, want 99", new(big.Int).SetBytes(m))
+	}
+
+	// Precompute must be idempotent: re-running it from the retained primes
+	// reproduces the same CRT values GenerateKey computed.
+	pp, qq, hp, hq := priv.PP, priv.QQ, priv.Hp, priv.Hq
+	priv.Precompute()
+	if priv.PP.Cmp(pp) != 0 || priv.QQ.Cmp(qq) != 0 || priv.Hp.Cmp(hp) != 0 || priv.Hq.Cmp(hq) != 0 {
+		t.Fatal("Precompute is not idempotent")
+	}
+}
+
+func TestEncryptRejectsMessageNotSmallerThanN(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	if _, err := priv.Encrypt(priv.N.Bytes()); err != ErrLargeMessage {
+		t.Fatalf("Encrypt(N): got err %v, want ErrLargeMessage", err)
+	}
+}
+
+func TestEncryptSamplesWithinZNStar(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	for i := 0; i < 20; i++ {
+		r, err := randomInZNStar(rand.Reader, priv.N)
+		if err != nil {
+			t.Fatalf("randomInZNStar: %v", err)
+		}
+		if r.Sign() <= 0 || r.Cmp(priv.N) >= 0 {
+			t.Fatalf("randomInZNStar returned %s, want in (0, N)", r)
+		}
+		if new(big.Int).GCD(nil, nil, r, priv.N).Cmp(one) != 0 {
+			t.Fatalf("randomInZNStar returned %s, not coprime to N", r)
+		}
+	}
+}
+
+func TestHommorphicEncMultiple(t *testing.T) {
+	priv, err := GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	var ciphers [][]byte
+	sum := int64(0)
+	for _, v := range []int64{1, 2, 3, 4} {
+		c, err := priv.Encrypt(big.NewInt(v).Bytes())
+		if err != nil {
+			t.Fatalf("Encrypt(%d): %v", v, err)
+		}
+		ciphers = append(ciphers, c)
+		sum += v
+	}
+
+	c, err := priv.HommorphicEncMultiple(ciphers...)
+	if err != nil {
+		t.Fatalf("HommorphicEncMultiple: %v", err)
+	}
+	m, err := priv.Decrypt(nil, c, nil)
+	if err != nil {
+		t.Fatalf("Decrypt: %v", err)
+	}
+	if got := new(big.Int).SetBytes(m); got.Cmp(big.NewInt(sum)) != 0 {
+		t.Fatalf("got %s, want %d", got, sum)
+	}
+}
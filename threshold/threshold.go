@@ -0,0 +1,216 @@
+// Package threshold implements threshold Paillier decryption: a PrivateKey's
+// secret is split via Shamir secret sharing so that any T of N parties can
+// jointly decrypt a ciphertext without ever reconstructing the full key.
+package threshold
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+
+	original "github.com/mirzazhar/paillier/original"
+)
+
+var (
+	one = big.NewInt(1)
+	two = big.NewInt(2)
+)
+
+var (
+	ErrInvalidThreshold = errors.New("threshold: t must satisfy 1 <= t <= numShares")
+	ErrNotEnoughShares  = errors.New("threshold: fewer than t partial decryptions supplied")
+	ErrDuplicateIndex   = errors.New("threshold: duplicate share index in partial decryptions")
+)
+
+// ThresholdKey is the public half of a threshold Paillier key pair: the
+// usual Paillier modulus and generator, plus the parameters needed to
+// combine partial decryptions produced by the key's shares.
+type ThresholdKey struct {
+	original.PublicKey
+	T         int      // minimum number of shares required to decrypt
+	NumShares int      // total number of shares generated
+	Delta     *big.Int // NumShares!, scales Lagrange coefficients to integers
+}
+
+// KeyShare is one party's share (i, f(i)) of the Shamir-shared private key,
+// where f is the degree T-1 polynomial whose constant term is d, the
+// combined decryption exponent (d = 0 mod L, d = 1 mod N).
+type KeyShare struct {
+	Index int
+	Share *big.Int
+}
+
+// PartialDecryption is a single party's contribution toward decrypting a
+// ciphertext, produced by PartialDecrypt without revealing that party's
+// KeyShare or reconstructing the private key.
+type PartialDecryption struct {
+	Index int
+	Value *big.Int
+}
+
+// GenerateThresholdKey generates a bits-sized Paillier key pair and splits
+// its private key into numShares KeyShares such that any t of them can
+// later decrypt via PartialDecrypt and Combine. It mirrors the Shamir
+// secret-sharing scheme over the combined decryption exponent d (d = 0 mod
+// L, d = 1 mod N, with L = phi(N)): the polynomial's constant term is d,
+// its higher coefficients are random modulo N*L, and Delta = numShares!
+// keeps the Lagrange coefficients used during Combine integral.
+func GenerateThresholdKey(random io.Reader, bits, t, numShares int) (*ThresholdKey, []*KeyShare, error) {
+	if t < 1 || t > numShares {
+		return nil, nil, ErrInvalidThreshold
+	}
+
+	p, err := rand.Prime(random, bits/2)
+	if err != nil {
+		return nil, nil, err
+	}
+	q, err := rand.Prime(random, bits/2)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	n := new(big.Int).Mul(p, q)
+	g := new(big.Int).Add(n, one)
+	nSquared := new(big.Int).Mul(n, n)
+
+	l := new(big.Int).Mul(
+		new(big.Int).Sub(p, one),
+		new(big.Int).Sub(q, one),
+	)
+
+	// m = N*L is the order of Z_{N^2}^*, so reducing every coefficient
+	// and f(i) modulo m leaves c^f(i) mod N^2 unchanged, which is all
+	// PartialDecrypt and Combine need.
+	m := new(big.Int).Mul(n, l)
+
+	// The shared secret is d, not L itself: d = L*(L^-1 mod N) satisfies
+	// d = 0 mod L and d = 1 mod N, which is exactly the exponent Combine's
+	// single L(.)*(4*Delta^2)^-1 mod N step needs to recover m directly
+	// (sharing L alone leaves an extra factor of L in the result).
+	lInvModN := new(big.Int).ModInverse(l, n)
+	d := new(big.Int).Mod(new(big.Int).Mul(l, lInvModN), m)
+
+	coeffs := make([]*big.Int, t)
+	coeffs[0] = d
+	for i := 1; i < t; i++ {
+		a, err := rand.Int(random, m)
+		if err != nil {
+			return nil, nil, err
+		}
+		coeffs[i] = a
+	}
+
+	shares := make([]*KeyShare, numShares)
+	for i := 1; i <= numShares; i++ {
+		shares[i-1] = &KeyShare{
+			Index: i,
+			Share: evalPoly(coeffs, i, m),
+		}
+	}
+
+	key := &ThresholdKey{
+		PublicKey: original.PublicKey{N: n, G: g, NSquared: nSquared},
+		T:         t,
+		NumShares: numShares,
+		Delta:     factorial(numShares),
+	}
+	return key, shares, nil
+}
+
+// PartialDecrypt computes one party's contribution c_i = c^(2*Delta*f(i))
+// mod N^2 toward decrypting cipherText, using only that party's KeyShare.
+func PartialDecrypt(pub *ThresholdKey, cipherText []byte, share *KeyShare) (*PartialDecryption, error) {
+	c := new(big.Int).SetBytes(cipherText)
+	if c.Cmp(pub.NSquared) >= 0 {
+		return nil, original.ErrLargeCipher
+	}
+
+	exp := new(big.Int).Mul(two, pub.Delta)
+	exp.Mul(exp, share.Share)
+	ci := new(big.Int).Exp(c, exp, pub.NSquared)
+
+	return &PartialDecryption{Index: share.Index, Value: ci}, nil
+}
+
+// Combine merges at least pub.T PartialDecryptions of cipherText into the
+// plaintext, interpolating the Shamir polynomial at zero via Lagrange
+// coefficients scaled by Delta. It returns ErrNotEnoughShares if fewer than
+// pub.T partials are supplied, or ErrDuplicateIndex if two partials name the
+// same party.
+func Combine(pub *ThresholdKey, cipherText []byte, partials []*PartialDecryption) ([]byte, error) {
+	if len(partials) < pub.T {
+		return nil, ErrNotEnoughShares
+	}
+
+	seen := make(map[int]bool, len(partials))
+	for _, p := range partials {
+		if seen[p.Index] {
+			return nil, ErrDuplicateIndex
+		}
+		seen[p.Index] = true
+	}
+	partials = partials[:pub.T]
+
+	// c' = prod_i c_i^(2*Delta*lambda_i) mod N^2
+	acc := big.NewInt(1)
+	for _, p := range partials {
+		lambda := lagrangeCoefficient(pub.Delta, p.Index, partials)
+		exp := new(big.Int).Mul(two, lambda)
+		term := new(big.Int).Exp(p.Value, exp, pub.NSquared)
+		acc.Mod(acc.Mul(acc, term), pub.NSquared)
+	}
+
+	// L(c') = (c' - 1) / N
+	l := new(big.Int).Div(new(big.Int).Sub(acc, one), pub.N)
+
+	// m = L(c') * (4*Delta^2)^-1 mod N
+	fourDeltaSq := new(big.Int).Mul(big.NewInt(4), new(big.Int).Mul(pub.Delta, pub.Delta))
+	inv := new(big.Int).ModInverse(fourDeltaSq, pub.N)
+	if inv == nil {
+		return nil, ErrNotEnoughShares
+	}
+	m := new(big.Int).Mod(new(big.Int).Mul(l, inv), pub.N)
+	return m.Bytes(), nil
+}
+
+// lagrangeCoefficient returns Delta*lambda_i, where lambda_i is party i's
+// Lagrange coefficient for interpolating the sharing polynomial at x=0
+// using only the parties present in partials. Multiplying by Delta (chosen
+// as numShares!) cancels the coefficient's denominator, so the result is
+// always an integer.
+func lagrangeCoefficient(delta *big.Int, i int, partials []*PartialDecryption) *big.Int {
+	num := new(big.Int).Set(delta)
+	den := big.NewInt(1)
+	for _, p := range partials {
+		j := p.Index
+		if j == i {
+			continue
+		}
+		num.Mul(num, big.NewInt(int64(-j)))
+		den.Mul(den, big.NewInt(int64(i-j)))
+	}
+	return num.Div(num, den)
+}
+
+// evalPoly evaluates the polynomial with the given coefficients (lowest
+// degree first) at x, reduced modulo m.
+func evalPoly(coeffs []*big.Int, x int, m *big.Int) *big.Int {
+	result := big.NewInt(0)
+	xBig := big.NewInt(int64(x))
+	power := big.NewInt(1)
+	for _, c := range coeffs {
+		result.Add(result, new(big.Int).Mul(c, power))
+		power.Mul(power, xBig)
+	}
+	return result.Mod(result, m)
+}
+
+// factorial returns n! as a big.Int.
+func factorial(n int) *big.Int {
+	f := big.NewInt(1)
+	for i := int64(2); i <= int64(n); i++ {
+		f.Mul(f, big.NewInt(i))
+	}
+	return f
+}
@@ -0,0 +1,116 @@
+package threshold
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	original "github.com/mirzazhar/paillier/original"
+)
+
+func encryptFor(t *testing.T, pub *ThresholdKey, m int64) []byte {
+	t.Helper()
+	pk := &original.PublicKey{N: pub.N, G: pub.G, NSquared: pub.NSquared}
+	c, err := pk.Encrypt(big.NewInt(m).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	return c
+}
+
+func decryptWith(t *testing.T, pub *ThresholdKey, cipherText []byte, shares []*KeyShare) *big.Int {
+	t.Helper()
+	partials := make([]*PartialDecryption, len(shares))
+	for i, s := range shares {
+		p, err := PartialDecrypt(pub, cipherText, s)
+		if err != nil {
+			t.Fatalf("PartialDecrypt: %v", err)
+		}
+		partials[i] = p
+	}
+	m, err := Combine(pub, cipherText, partials)
+	if err != nil {
+		t.Fatalf("Combine: %v", err)
+	}
+	return new(big.Int).SetBytes(m)
+}
+
+func TestThresholdDecryptAnyTOfN(t *testing.T) {
+	const bits, tt, n = 256, 3, 5
+	pub, shares, err := GenerateThresholdKey(rand.Reader, bits, tt, n)
+	if err != nil {
+		t.Fatalf("GenerateThresholdKey: %v", err)
+	}
+
+	const want = 42
+	c := encryptFor(t, pub, want)
+
+	// Each of these combinations of t=3 shares out of 5 must recover the
+	// plaintext.
+	subsets := [][]int{{0, 1, 2}, {0, 2, 4}, {1, 3, 4}, {2, 3, 4}}
+	for _, idx := range subsets {
+		subset := make([]*KeyShare, len(idx))
+		for i, j := range idx {
+			subset[i] = shares[j]
+		}
+		got := decryptWith(t, pub, c, subset)
+		if got.Int64() != want {
+			t.Errorf("subset %v: got %s, want %d", idx, got, want)
+		}
+	}
+}
+
+func TestThresholdCombineRejectsTMinusOne(t *testing.T) {
+	const bits, tt, n = 256, 3, 5
+	pub, shares, err := GenerateThresholdKey(rand.Reader, bits, tt, n)
+	if err != nil {
+		t.Fatalf("GenerateThresholdKey: %v", err)
+	}
+
+	c := encryptFor(t, pub, 7)
+
+	partials := make([]*PartialDecryption, 0, tt-1)
+	for _, s := range shares[:tt-1] {
+		p, err := PartialDecrypt(pub, c, s)
+		if err != nil {
+			t.Fatalf("PartialDecrypt: %v", err)
+		}
+		partials = append(partials, p)
+	}
+
+	if _, err := Combine(pub, c, partials); err != ErrNotEnoughShares {
+		t.Fatalf("Combine with t-1 shares: got err %v, want ErrNotEnoughShares", err)
+	}
+}
+
+func TestThresholdCombineRejectsDuplicateIndex(t *testing.T) {
+	const bits, tt, n = 256, 2, 3
+	pub, shares, err := GenerateThresholdKey(rand.Reader, bits, tt, n)
+	if err != nil {
+		t.Fatalf("GenerateThresholdKey: %v", err)
+	}
+
+	c := encryptFor(t, pub, 1)
+
+	p0, err := PartialDecrypt(pub, c, shares[0])
+	if err != nil {
+		t.Fatalf("PartialDecrypt: %v", err)
+	}
+	dup, err := PartialDecrypt(pub, c, shares[0])
+	if err != nil {
+		t.Fatalf("PartialDecrypt: %v", err)
+	}
+
+	if _, err := Combine(pub, c, []*PartialDecryption{p0, dup}); err != ErrDuplicateIndex {
+		t.Fatalf("Combine with duplicate index: got err %v, want ErrDuplicateIndex", err)
+	}
+}
+
+func TestGenerateThresholdKeyInvalidThreshold(t *testing.T) {
+	if _, _, err := GenerateThresholdKey(rand.Reader, 256, 0, 3); err != ErrInvalidThreshold {
+		t.Errorf("t=0: got err %v, want ErrInvalidThreshold", err)
+	}
+	if _, _, err := GenerateThresholdKey(rand.Reader, 256, 4, 3); err != ErrInvalidThreshold {
+		t.Errorf("t>numShares: got err %v, want ErrInvalidThreshold", err)
+	}
+}
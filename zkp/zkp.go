@@ -0,0 +1,221 @@
+// Package zkp implements zero-knowledge proofs for Paillier ciphertexts:
+// proof of plaintext knowledge and proof of correct decryption, the two
+// Sigma-protocols commonly layered on Paillier for verifiable MPC and
+// e-voting.
+package zkp
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/asn1"
+	"errors"
+	"math/big"
+
+	original "github.com/mirzazhar/paillier/original"
+)
+
+var one = big.NewInt(1)
+
+// ErrInvalidProof is returned by the Verify* functions when a proof does not
+// hold.
+var ErrInvalidProof = errors.New("zkp: proof verification failed")
+
+// KnowledgeProof is a non-interactive (Fiat–Shamir) Sigma-protocol proof
+// that the prover knows the plaintext m and randomness r underlying a
+// Paillier ciphertext c = g^m r^N mod N^2, without revealing either.
+type KnowledgeProof struct {
+	A  *big.Int // commitment: g^alpha * rho^N mod N^2
+	Z1 *big.Int // alpha + e*m
+	Z2 *big.Int // rho * r^e mod N^2
+}
+
+// ProveKnowledge builds a KnowledgeProof that cipherText encrypts m under
+// randomness r and public key pub.
+func ProveKnowledge(pub *original.PublicKey, cipherText []byte, m, r *big.Int) (*KnowledgeProof, error) {
+	c := new(big.Int).SetBytes(cipherText)
+
+	alpha, err := rand.Int(rand.Reader, pub.N)
+	if err != nil {
+		return nil, err
+	}
+	rho, err := randomUnit(pub.N)
+	if err != nil {
+		return nil, err
+	}
+
+	a := new(big.Int).Mod(
+		new(big.Int).Mul(
+			new(big.Int).Exp(pub.G, alpha, pub.NSquared),
+			new(big.Int).Exp(rho, pub.N, pub.NSquared),
+		),
+		pub.NSquared,
+	)
+
+	e := challenge(pub.N, pub.G, c, a)
+
+	z1 := new(big.Int).Add(alpha, new(big.Int).Mul(e, m))
+	z2 := new(big.Int).Mod(
+		new(big.Int).Mul(rho, new(big.Int).Exp(r, e, pub.NSquared)),
+		pub.NSquared,
+	)
+
+	return &KnowledgeProof{A: a, Z1: z1, Z2: z2}, nil
+}
+
+// VerifyKnowledge checks a KnowledgeProof against cipherText, returning
+// ErrInvalidProof if it does not hold.
+func VerifyKnowledge(pub *original.PublicKey, cipherText []byte, proof *KnowledgeProof) error {
+	c := new(big.Int).SetBytes(cipherText)
+	e := challenge(pub.N, pub.G, c, proof.A)
+
+	lhs := new(big.Int).Mod(
+		new(big.Int).Mul(
+			new(big.Int).Exp(pub.G, proof.Z1, pub.NSquared),
+			new(big.Int).Exp(proof.Z2, pub.N, pub.NSquared),
+		),
+		pub.NSquared,
+	)
+	rhs := new(big.Int).Mod(
+		new(big.Int).Mul(proof.A, new(big.Int).Exp(c, e, pub.NSquared)),
+		pub.NSquared,
+	)
+
+	if lhs.Cmp(rhs) != 0 {
+		return ErrInvalidProof
+	}
+	return nil
+}
+
+type knowledgeProofASN1 struct {
+	A, Z1, Z2 *big.Int
+}
+
+// Marshal serializes p to ASN.1 DER.
+func (p *KnowledgeProof) Marshal() ([]byte, error) {
+	return asn1.Marshal(knowledgeProofASN1{A: p.A, Z1: p.Z1, Z2: p.Z2})
+}
+
+// UnmarshalKnowledgeProof parses a KnowledgeProof produced by Marshal.
+func UnmarshalKnowledgeProof(der []byte) (*KnowledgeProof, error) {
+	var w knowledgeProofASN1
+	if _, err := asn1.Unmarshal(der, &w); err != nil {
+		return nil, err
+	}
+	return &KnowledgeProof{A: w.A, Z1: w.Z1, Z2: w.Z2}, nil
+}
+
+// VerificationKey derives the public value G^L mod N^2 that VerifyDecryption
+// checks DecryptionProofs against. It depends only on the key pair, not the
+// ciphertext, so it can be published once per key and reused for every
+// DecryptionProof from that key.
+func VerificationKey(priv *original.PrivateKey) *big.Int {
+	return new(big.Int).Exp(priv.G, priv.L, priv.NSquared)
+}
+
+// DecryptionProof is a Chaum–Pedersen style Sigma-protocol proof that a
+// revealed plaintext is the correct decryption of a ciphertext: it proves
+// equality of discrete logs between (cipherText, cipherText^lambda) and
+// (G, vk) without revealing lambda, where vk = VerificationKey(priv).
+type DecryptionProof struct {
+	CLambda *big.Int // c^lambda mod N^2, which the verifier checks against vk^m
+	A1      *big.Int // c^alpha mod N^2
+	A2      *big.Int // G^alpha mod N^2
+	Z       *big.Int // alpha + e*lambda
+}
+
+// ProveDecryption decrypts cipherText under priv and builds a
+// DecryptionProof that the returned plaintext is correct.
+func ProveDecryption(priv *original.PrivateKey, cipherText []byte) (plainText []byte, proof *DecryptionProof, err error) {
+	c := new(big.Int).SetBytes(cipherText)
+
+	cLambda := new(big.Int).Exp(c, priv.L, priv.NSquared)
+
+	alpha, err := rand.Int(rand.Reader, priv.NSquared)
+	if err != nil {
+		return nil, nil, err
+	}
+	a1 := new(big.Int).Exp(c, alpha, priv.NSquared)
+	a2 := new(big.Int).Exp(priv.G, alpha, priv.NSquared)
+
+	e := challenge(priv.N, c, cLambda, a1, a2)
+	z := new(big.Int).Add(alpha, new(big.Int).Mul(e, priv.L))
+
+	l := new(big.Int).Div(new(big.Int).Sub(cLambda, one), priv.N)
+	m := new(big.Int).Mod(new(big.Int).Mul(l, priv.U), priv.N)
+
+	return m.Bytes(), &DecryptionProof{CLambda: cLambda, A1: a1, A2: a2, Z: z}, nil
+}
+
+// VerifyDecryption checks that proof shows cipherText decrypts to plainText
+// under the key with verification value vk (see VerificationKey), returning
+// ErrInvalidProof if it does not hold.
+func VerifyDecryption(pub *original.PublicKey, vk *big.Int, cipherText, plainText []byte, proof *DecryptionProof) error {
+	c := new(big.Int).SetBytes(cipherText)
+
+	// vk^m and c^lambda both equal G^(m*lambda mod N) mod N^2, so this ties
+	// the claimed plaintext to the value the Sigma-protocol below is about.
+	expected := new(big.Int).Exp(vk, new(big.Int).SetBytes(plainText), pub.NSquared)
+	if expected.Cmp(proof.CLambda) != 0 {
+		return ErrInvalidProof
+	}
+
+	e := challenge(pub.N, c, proof.CLambda, proof.A1, proof.A2)
+
+	lhs1 := new(big.Int).Exp(c, proof.Z, pub.NSquared)
+	rhs1 := new(big.Int).Mod(new(big.Int).Mul(proof.A1, new(big.Int).Exp(proof.CLambda, e, pub.NSquared)), pub.NSquared)
+	if lhs1.Cmp(rhs1) != 0 {
+		return ErrInvalidProof
+	}
+
+	lhs2 := new(big.Int).Exp(pub.G, proof.Z, pub.NSquared)
+	rhs2 := new(big.Int).Mod(new(big.Int).Mul(proof.A2, new(big.Int).Exp(vk, e, pub.NSquared)), pub.NSquared)
+	if lhs2.Cmp(rhs2) != 0 {
+		return ErrInvalidProof
+	}
+
+	return nil
+}
+
+type decryptionProofASN1 struct {
+	CLambda, A1, A2, Z *big.Int
+}
+
+// Marshal serializes p to ASN.1 DER.
+func (p *DecryptionProof) Marshal() ([]byte, error) {
+	return asn1.Marshal(decryptionProofASN1{CLambda: p.CLambda, A1: p.A1, A2: p.A2, Z: p.Z})
+}
+
+// UnmarshalDecryptionProof parses a DecryptionProof produced by Marshal.
+func UnmarshalDecryptionProof(der []byte) (*DecryptionProof, error) {
+	var w decryptionProofASN1
+	if _, err := asn1.Unmarshal(der, &w); err != nil {
+		return nil, err
+	}
+	return &DecryptionProof{CLambda: w.CLambda, A1: w.A1, A2: w.A2, Z: w.Z}, nil
+}
+
+// challenge derives the Fiat–Shamir verifier challenge by hashing the public
+// parameters and the prover's commitment.
+func challenge(parts ...*big.Int) *big.Int {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write(p.Bytes())
+	}
+	return new(big.Int).SetBytes(h.Sum(nil))
+}
+
+// randomUnit samples a random element of Z_N^*.
+func randomUnit(n *big.Int) (*big.Int, error) {
+	for {
+		r, err := rand.Int(rand.Reader, n)
+		if err != nil {
+			return nil, err
+		}
+		if r.Sign() == 0 {
+			continue
+		}
+		if new(big.Int).GCD(nil, nil, r, n).Cmp(one) == 0 {
+			return r, nil
+		}
+	}
+}
@@ -0,0 +1,188 @@
+package zkp
+
+import (
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	original "github.com/mirzazhar/paillier/original"
+)
+
+// encrypt mirrors PublicKey.Encrypt but also returns the randomness r used,
+// since ProveKnowledge needs it as a witness.
+func encrypt(pub *original.PublicKey, m *big.Int) (cipherText []byte, r *big.Int, err error) {
+	for {
+		r, err = rand.Int(rand.Reader, pub.N)
+		if err != nil {
+			return nil, nil, err
+		}
+		if r.Sign() != 0 && new(big.Int).GCD(nil, nil, r, pub.N).Cmp(one) == 0 {
+			break
+		}
+	}
+
+	c := new(big.Int).Mod(
+		new(big.Int).Mul(
+			new(big.Int).Exp(pub.G, m, pub.NSquared),
+			new(big.Int).Exp(r, pub.N, pub.NSquared),
+		),
+		pub.NSquared,
+	)
+	return c.Bytes(), r, nil
+}
+
+func TestKnowledgeProofAcceptsValid(t *testing.T) {
+	priv, err := original.GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	m := big.NewInt(42)
+	c, r, err := encrypt(&priv.PublicKey, m)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	proof, err := ProveKnowledge(&priv.PublicKey, c, m, r)
+	if err != nil {
+		t.Fatalf("ProveKnowledge: %v", err)
+	}
+
+	if err := VerifyKnowledge(&priv.PublicKey, c, proof); err != nil {
+		t.Fatalf("VerifyKnowledge: %v", err)
+	}
+}
+
+func TestKnowledgeProofRejectsWrongCiphertext(t *testing.T) {
+	priv, err := original.GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	m := big.NewInt(42)
+	c, r, err := encrypt(&priv.PublicKey, m)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	proof, err := ProveKnowledge(&priv.PublicKey, c, m, r)
+	if err != nil {
+		t.Fatalf("ProveKnowledge: %v", err)
+	}
+
+	otherCipher, _, err := encrypt(&priv.PublicKey, big.NewInt(7))
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+
+	if err := VerifyKnowledge(&priv.PublicKey, otherCipher, proof); err != ErrInvalidProof {
+		t.Fatalf("got err %v, want ErrInvalidProof", err)
+	}
+}
+
+func TestKnowledgeProofMarshalRoundTrip(t *testing.T) {
+	priv, err := original.GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	m := big.NewInt(9)
+	c, r, err := encrypt(&priv.PublicKey, m)
+	if err != nil {
+		t.Fatalf("encrypt: %v", err)
+	}
+	proof, err := ProveKnowledge(&priv.PublicKey, c, m, r)
+	if err != nil {
+		t.Fatalf("ProveKnowledge: %v", err)
+	}
+
+	der, err := proof.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	got, err := UnmarshalKnowledgeProof(der)
+	if err != nil {
+		t.Fatalf("UnmarshalKnowledgeProof: %v", err)
+	}
+
+	if err := VerifyKnowledge(&priv.PublicKey, c, got); err != nil {
+		t.Fatalf("VerifyKnowledge after round-trip: %v", err)
+	}
+}
+
+func TestDecryptionProofAcceptsValid(t *testing.T) {
+	priv, err := original.GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(42).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	m, proof, err := ProveDecryption(priv, c)
+	if err != nil {
+		t.Fatalf("ProveDecryption: %v", err)
+	}
+	if new(big.Int).SetBytes(m).Cmp(big.NewInt(42)) != 0 {
+		t.Fatalf("got plaintext %s, want 42", new(big.Int).SetBytes(m))
+	}
+
+	vk := VerificationKey(priv)
+	if err := VerifyDecryption(&priv.PublicKey, vk, c, m, proof); err != nil {
+		t.Fatalf("VerifyDecryption: %v", err)
+	}
+}
+
+func TestDecryptionProofRejectsWrongPlaintext(t *testing.T) {
+	priv, err := original.GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(42).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+
+	_, proof, err := ProveDecryption(priv, c)
+	if err != nil {
+		t.Fatalf("ProveDecryption: %v", err)
+	}
+
+	vk := VerificationKey(priv)
+	wrong := big.NewInt(43).Bytes()
+	if err := VerifyDecryption(&priv.PublicKey, vk, c, wrong, proof); err != ErrInvalidProof {
+		t.Fatalf("got err %v, want ErrInvalidProof", err)
+	}
+}
+
+func TestDecryptionProofMarshalRoundTrip(t *testing.T) {
+	priv, err := original.GenerateKey(rand.Reader, 256)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+
+	c, err := priv.Encrypt(big.NewInt(5).Bytes())
+	if err != nil {
+		t.Fatalf("Encrypt: %v", err)
+	}
+	m, proof, err := ProveDecryption(priv, c)
+	if err != nil {
+		t.Fatalf("ProveDecryption: %v", err)
+	}
+
+	der, err := proof.Marshal()
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	got, err := UnmarshalDecryptionProof(der)
+	if err != nil {
+		t.Fatalf("UnmarshalDecryptionProof: %v", err)
+	}
+
+	vk := VerificationKey(priv)
+	if err := VerifyDecryption(&priv.PublicKey, vk, c, m, got); err != nil {
+		t.Fatalf("VerifyDecryption after round-trip: %v", err)
+	}
+}